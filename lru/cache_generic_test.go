@@ -0,0 +1,206 @@
+package lru
+
+import (
+	"testing"
+)
+
+func TestGenericCache_EmptyCache(t *testing.T) {
+	cache, _ := NewCache[string, string](128, nil, nil)
+
+	if cache.EmptySpace() != 128 {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_NoProducer(t *testing.T) {
+	cache, _ := NewCache[string, string](128, nil, nil)
+
+	_, ok := cache.Get("test")
+
+	if ok {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_Producer(t *testing.T) {
+	cache, _ := NewCache(128, func(key string) (string, int, bool) {
+		if key == "test" {
+			return "test-value", 100, true
+		}
+		return "", 0, false
+	}, nil)
+
+	value, ok := cache.Get("test")
+
+	if !ok || value != "test-value" {
+		t.Fail()
+	}
+	if cache.EmptySpace() != 28 {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_Eviction(t *testing.T) {
+	cache, _ := NewCache(128, func(key string) (string, int, bool) {
+		return key, 50, true
+	}, nil)
+
+	cache.Get("test1")
+	cache.Get("test2")
+	cache.Get("test3")
+
+	if cache.Has("test1") {
+		t.Fail()
+	}
+	if !cache.Has("test2") || !cache.Has("test3") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_PutTypedValue(t *testing.T) {
+	type payload struct {
+		Name string
+	}
+
+	cache, _ := NewCache[int, payload](128, nil, nil)
+
+	if err := cache.Put(1, payload{Name: "one"}, 50); err != nil {
+		t.Fail()
+	}
+
+	value, ok := cache.Get(1)
+	if !ok || value.Name != "one" {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_Replace(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 50)
+	cache.Replace("test1", 2, 50)
+
+	value, _ := cache.Get("test1")
+	if value != 2 {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_Evict(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 50)
+	value, ok := cache.Evict("test1")
+
+	if !ok || value != 1 {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_EvictIf(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 10)
+	cache.Put("test2", 2, 10)
+	cache.Put("test3", 3, 10)
+
+	cache.EvictIf(func(key string) bool {
+		return key == "test2"
+	})
+
+	if !cache.Has("test1") || cache.Has("test2") || !cache.Has("test3") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_GetFreshSeesCurrentValue(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 10)
+	cache.Replace("test1", 2, 10)
+
+	// The staleness check must run against the value live at lock time, not one
+	// read earlier, or a concurrent Replace could be clobbered by a stale check.
+	value, ok := cache.GetFresh("test1", func(v int) bool { return v == 1 })
+	if !ok || value != 2 {
+		t.Fail()
+	}
+	if !cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_GetFreshEvictsStaleValue(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 10)
+
+	_, ok := cache.GetFresh("test1", func(v int) bool { return v == 1 })
+	if ok {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_HasFreshEvictsStaleValue(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 10)
+
+	if cache.HasFresh("test1", func(v int) bool { return v == 1 }) {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_EvictIfCurrentSkipsChangedValue(t *testing.T) {
+	cache, _ := NewCache[string, int](100, nil, nil)
+
+	cache.Put("test1", 1, 10)
+	cache.Replace("test1", 2, 10)
+
+	// matches is evaluated against the current value, so a stale caller holding
+	// onto the old value (1) cannot evict the entry once it's been replaced.
+	if _, evicted := cache.EvictIfCurrent("test1", func(v int) bool { return v == 1 }); evicted {
+		t.Fail()
+	}
+	if !cache.Has("test1") {
+		t.Fail()
+	}
+
+	value, evicted := cache.EvictIfCurrent("test1", func(v int) bool { return v == 2 })
+	if !evicted || value != 2 {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestGenericCache_OnEvict(t *testing.T) {
+	evicted := ""
+
+	cache, _ := NewCache(128, nil, func(key string, value string, size int) {
+		evicted += key
+	})
+
+	cache.Put("test1", "a", 50)
+	cache.Put("test2", "b", 50)
+
+	if evicted != "" {
+		t.Fail()
+	}
+
+	cache.Put("test3", "c", 100)
+
+	if evicted != "test1test2" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,80 @@
+package lru
+
+import (
+	"testing"
+)
+
+func TestEvictBuffer_DispatchesAfterLock(t *testing.T) {
+	evicted := ""
+
+	cache, _ := New(100, nil, func(key interface{}, item *LRUItem) {
+		evicted += key.(string)
+	}, WithEvictBuffer(4))
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 50})
+
+	if evicted != "" {
+		t.Fail()
+	}
+
+	cache.Put("test3", &LRUItem{Value: "test3", Size: 50})
+
+	if evicted != "test1" {
+		t.Fail()
+	}
+}
+
+func TestEvictBuffer_Drain(t *testing.T) {
+	cache, _ := New(100, nil, nil, WithEvictBuffer(4))
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 50})
+	cache.Put("test3", &LRUItem{Value: "test3", Size: 50})
+
+	batch := cache.Drain()
+
+	if len(batch) != 1 || batch[0].Key != "test1" {
+		t.Fail()
+	}
+	if len(cache.Drain()) != 0 {
+		t.Fail()
+	}
+}
+
+func TestEvictBuffer_DrainAfterEvictIf(t *testing.T) {
+	cache, _ := New(100, nil, nil, WithEvictBuffer(4))
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 10})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 10})
+
+	cache.EvictIf(func(key interface{}) bool {
+		return key == "test1"
+	})
+
+	batch := cache.Drain()
+	if len(batch) != 1 || batch[0].Key != "test1" {
+		t.Fail()
+	}
+}
+
+func TestEvictBuffer_WithoutOption_DispatchesInline(t *testing.T) {
+	evicted := ""
+
+	cache, _ := New(100, nil, func(key interface{}, item *LRUItem) {
+		evicted += key.(string)
+	})
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 50})
+
+	if evicted != "" {
+		t.Fail()
+	}
+
+	cache.Put("test3", &LRUItem{Value: "test3", Size: 50})
+
+	if evicted != "test1" {
+		t.Fail()
+	}
+}
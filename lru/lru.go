@@ -8,61 +8,59 @@ import (
 	"container/list"
 	"errors"
 	"sync"
+	"time"
 )
 
-// LRU cache used for storing items of arbitrary size.
-type LRUCache struct {
+// Cache is a generic LRU cache for items of arbitrary size, parameterised over
+// a comparable key type K and an arbitrary value type V. It holds the actual
+// cache internals; LRUCache below is a thin interface{}-based wrapper kept for
+// backwards compatibility so existing callers aren't forced onto type parameters.
+type Cache[K comparable, V any] struct {
 	// Maximum size the cache can hold
 	maxSize int
 	// Current occupied size
 	currentSize int
 
-	// Cached items sorted by the last time they were read. The last item(s)
+	// Entries sorted by the last time they were read. The last item(s)
 	// in this queue will be evicted if no more space is left
 	orderedItems *list.List
-	// Map of item key to value
-	items map[interface{}]*LRUItem
+	// Map of item key to its list element
+	items map[K]*list.Element
 
-	// In case of cache-mises, this producer will create the item to be stored in the cache
-	producer func(interface{}) *LRUItem
+	// In case of cache-misses, this producer will create the item to be stored in the cache.
+	// It returns the value, its size, and whether an item was produced at all.
+	producer func(key K) (V, int, bool)
 	// Callback when an item is evicted from the cache
-	onevict func(interface{}, *LRUItem)
+	onevict func(K, V, int)
 
 	// Mutex used to synchronise cache operations
 	rwlock sync.RWMutex
 }
 
-// Single cache item
-type LRUItem struct {
-	// Actual value stored for this specific item
-	Value interface{}
-	// The size for this item
-	Size int
-
-	// The list element stored in the "orderedList" list for this item. Used for faster
-	// cache manipulation
-	elementListItem *list.Element
-	// The key for this cache, as it's stored within the "items" map
-	elementKey *interface{}
+// cacheEntry is the value stored in orderedItems/items for a given key.
+type cacheEntry[K comparable, V any] struct {
+	key   K
+	value V
+	size  int
 }
 
-// New Creates a new LRW cache
+// NewCache creates a new generic LRU cache.
 // @param {maxSize} - the maximum size of this cache
-// @param {producer} - optional callback function which gets invoked on cache misses. The
-// 	result of this callback is stored in the cache for the key which was missing.
-// 	Can be null, in which case all cache misses will return null
+// @param {producer} - optional callback invoked on cache misses. It returns the value to
+// 	store, its size, and whether an item was produced at all. Can be nil, in which case
+// 	all cache misses return the zero value of V and false.
 // @param {onevict} - optional function which will get called when an item is evicted from the cache
-func New(maxSize int, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem)) (*LRUCache, error) {
+func NewCache[K comparable, V any](maxSize int, producer func(key K) (V, int, bool), onevict func(K, V, int)) (*Cache[K, V], error) {
 	if maxSize <= 0 {
 		return nil, errors.New("Cache size must be greater than 0")
 	}
 
-	cache := &LRUCache{
+	cache := &Cache[K, V]{
 		maxSize:     maxSize,
 		currentSize: 0,
 
 		orderedItems: list.New(),
-		items:        make(map[interface{}]*LRUItem),
+		items:        make(map[K]*list.Element),
 
 		producer: producer,
 		onevict:  onevict,
@@ -71,73 +69,106 @@ func New(maxSize int, producer func(key interface{}) *LRUItem, onevict func(inte
 }
 
 // Get retrieves an element from the cache. In case of cache miss and a producer is defined, then
-// the producer is invoked and the result is stored in the cache. If the producer is null, no
-// result is returned (nil return value)
-//
-// @param key - the key to search in the cache
-// @return - if found, returns the corresponding cached element. If not found, the result
-// 	can either be null if the producer was not given, or the retult of the producer
-func (cache *LRUCache) Get(key interface{}) *LRUItem {
+// the producer is invoked and the result is stored in the cache. If the producer is nil, or it
+// reports no item, the zero value of V and false are returned.
+func (cache *Cache[K, V]) Get(key K) (V, bool) {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
 	if element, ok := cache.items[key]; ok {
-		cache.orderedItems.MoveToFront(element.elementListItem)
-		return element
-	} else {
-		return cache.produceItem(key)
+		cache.orderedItems.MoveToFront(element)
+		return element.Value.(*cacheEntry[K, V]).value, true
 	}
+	return cache.produce(key)
 }
 
-func (cache *LRUCache) produceItem(key interface{}) *LRUItem {
-	if cache.producer == nil {
-		return nil
+// GetFresh behaves like Get, except a hit is first passed to stale: if stale
+// reports true, the entry is evicted under the same lock acquisition and
+// treated as a miss instead of being promoted and returned. This lets callers
+// (LRUCache's lazy TTL expiry) check-and-evict atomically, rather than racing
+// a separate Evict call against a concurrent write to the same key.
+func (cache *Cache[K, V]) GetFresh(key K, stale func(V) bool) (V, bool) {
+	cache.rwlock.Lock()
+	defer cache.rwlock.Unlock()
+
+	if element, ok := cache.items[key]; ok {
+		entry := element.Value.(*cacheEntry[K, V])
+		if stale != nil && stale(entry.value) {
+			cache.evictElement(element)
+			return cache.produce(key)
+		}
+		cache.orderedItems.MoveToFront(element)
+		return entry.value, true
 	}
+	return cache.produce(key)
+}
 
-	element := cache.producer(key)
+func (cache *Cache[K, V]) produce(key K) (V, bool) {
+	var zero V
+	if cache.producer == nil {
+		return zero, false
+	}
 
-	if element == nil {
-		return nil
+	value, size, ok := cache.producer(key)
+	if !ok {
+		return zero, false
 	}
 
-	cache.putItem(&key, element)
-	return element
+	cache.putItem(key, value, size)
+	return value, true
 }
 
-func (cache *LRUCache) putItem(key *interface{}, element *LRUItem) {
-	cache.currentSize += element.Size
+func (cache *Cache[K, V]) putItem(key K, value V, size int) {
+	cache.currentSize += size
 
-	listElement := cache.orderedItems.PushFront(element)
-	cache.items[*key] = element
-
-	element.elementListItem = listElement
-	element.elementKey = key
+	element := cache.orderedItems.PushFront(&cacheEntry[K, V]{key: key, value: value, size: size})
+	cache.items[key] = element
 
 	cache.evictAsNeeded()
 }
 
-func (cache *LRUCache) evictAsNeeded() {
+func (cache *Cache[K, V]) evictAsNeeded() {
 	for cache.currentSize > cache.maxSize && cache.orderedItems.Len() > 0 {
-		back := cache.orderedItems.Back()
-		cache.evictElement(back.Value.(*LRUItem))
+		cache.evictElement(cache.orderedItems.Back())
 	}
 }
 
-func (cache *LRUCache) evictElement(element *LRUItem) {
-	cache.currentSize -= element.Size
+func (cache *Cache[K, V]) evictElement(element *list.Element) {
+	entry := element.Value.(*cacheEntry[K, V])
 
-	delete(cache.items, *element.elementKey)
-	cache.orderedItems.Remove(element.elementListItem)
+	cache.currentSize -= entry.size
+	delete(cache.items, entry.key)
+	cache.orderedItems.Remove(element)
 
 	if cache.onevict != nil {
-		cache.onevict(*element.elementKey, element)
+		cache.onevict(entry.key, entry.value, entry.size)
+	}
+}
+
+// takeWithoutEvict removes key from the cache without invoking onevict, for callers
+// (such as TwoQueueCache) that need to move an entry elsewhere rather than truly evict it.
+func (cache *Cache[K, V]) takeWithoutEvict(key K) (V, bool) {
+	cache.rwlock.Lock()
+	defer cache.rwlock.Unlock()
+
+	var zero V
+	element, ok := cache.items[key]
+	if !ok {
+		return zero, false
 	}
+
+	entry := element.Value.(*cacheEntry[K, V])
+	cache.currentSize -= entry.size
+	delete(cache.items, key)
+	cache.orderedItems.Remove(element)
+
+	return entry.value, true
 }
 
 // MakeRoom evicts elements from the cache until the specified empty space is made. Ff the
 // cache already has enough empty space, then no elements are evicted.
 // @param  {int} size - how much empty space should be ensured
-func (cache *LRUCache) MakeRoom(size int) {
+func (cache *Cache[K, V]) MakeRoom(size int) {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
@@ -148,67 +179,93 @@ func (cache *LRUCache) MakeRoom(size int) {
 
 // Put adds a new element to the cache. If an item with the same key already exists,
 // then the operation fails and an error is returned.
-// @param {interface{}} key - the key for the new cached item
-// @param {*LRUItem} element - the element to be inserted
-func (cache *LRUCache) Put(key interface{}, element *LRUItem) error {
+// @param {K} key - the key for the new cached item
+// @param {V} value - the value to be inserted
+// @param {int} size - the size of the value, used against the cache's maxSize budget
+func (cache *Cache[K, V]) Put(key K, value V, size int) error {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
 	if _, ok := cache.items[key]; ok {
 		return errors.New("Key already exists")
 	}
-	cache.putItem(&key, element)
+	cache.putItem(key, value, size)
 	return nil
 }
 
 // Replace adds a new element to the cache. If an item with the same key already exists,
-// then it is evicted and replaced with the given element.
-// @param {interface{}} key - the key for the new cached item
-// @param {*LRUItem} element - the element to be inserted
-func (cache *LRUCache) Replace(key interface{}, element *LRUItem) error {
+// then it is evicted and replaced with the given value.
+// @param {K} key - the key for the new cached item
+// @param {V} value - the value to be inserted
+// @param {int} size - the size of the value, used against the cache's maxSize budget
+func (cache *Cache[K, V]) Replace(key K, value V, size int) error {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
-	if item, ok := cache.items[key]; ok {
-		cache.evictElement(item)
+	if element, ok := cache.items[key]; ok {
+		cache.evictElement(element)
 	}
-	cache.putItem(&key, element)
+	cache.putItem(key, value, size)
 	return nil
 }
 
 // Evict removes an element from the cache.
-// @param {interface{}} key - the key of the element to be removed
-// @return {*LRUItem} - if an element with the specified key is found and removed, then the return
-// value is the deleted element. Otherwise, nil is returned.
-func (cache *LRUCache) Evict(key interface{}) *LRUItem {
+// @param {K} key - the key of the element to be removed
+// @return {V, bool} - if an element with the specified key is found and removed, then the
+// return value is the deleted value and true. Otherwise, the zero value and false are returned.
+func (cache *Cache[K, V]) Evict(key K) (V, bool) {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
-	if item, ok := cache.items[key]; ok {
-		cache.evictElement(item)
-		return item
+	var zero V
+	element, ok := cache.items[key]
+	if !ok {
+		return zero, false
 	}
-	return nil
+
+	value := element.Value.(*cacheEntry[K, V]).value
+	cache.evictElement(element)
+	return value, true
 }
 
-// Evict removes an element from the cache.
-// @param {interface{}} key - the key of the element to be removed
-// @return {*LRUItem} - if an element with the specified key is found and removed, then the return
-// value is the deleted element. Otherwise, nil is returned.
-func (cache *LRUCache) EvictIf(predicate func(interface{}) bool) *LRUItem {
+// EvictIfCurrent evicts key's entry, but only if matches reports true for the value
+// still stored under key, all under a single lock acquisition. This lets a caller
+// that observed a (possibly stale) value earlier evict it without racing a concurrent
+// Put/Replace for the same key: if the value changed in between, matches sees the new
+// one and declines, so the fresh entry survives.
+func (cache *Cache[K, V]) EvictIfCurrent(key K, matches func(V) bool) (V, bool) {
+	cache.rwlock.Lock()
+	defer cache.rwlock.Unlock()
+
+	var zero V
+	element, ok := cache.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := element.Value.(*cacheEntry[K, V])
+	if !matches(entry.value) {
+		return zero, false
+	}
+	cache.evictElement(element)
+	return entry.value, true
+}
+
+// EvictIf removes every element whose key matches predicate.
+// @param {func(K) bool} predicate - called once per key currently in the cache
+func (cache *Cache[K, V]) EvictIf(predicate func(K) bool) {
 	cache.rwlock.Lock()
 	defer cache.rwlock.Unlock()
 
-	for key, value := range cache.items {
+	for key, element := range cache.items {
 		if predicate(key) {
-			cache.evictElement(value)
+			cache.evictElement(element)
 		}
 	}
-	return nil
 }
 
 // EmptySpace returns the remaining empty space within the cache.
-func (cache *LRUCache) EmptySpace() int {
+func (cache *Cache[K, V]) EmptySpace() int {
 	cache.rwlock.RLock()
 	defer cache.rwlock.RUnlock()
 
@@ -216,7 +273,7 @@ func (cache *LRUCache) EmptySpace() int {
 }
 
 // MaxSize returns the maximum size this cache can hold.
-func (cache *LRUCache) MaxSize() int {
+func (cache *Cache[K, V]) MaxSize() int {
 	cache.rwlock.RLock()
 	defer cache.rwlock.RUnlock()
 
@@ -224,13 +281,334 @@ func (cache *LRUCache) MaxSize() int {
 }
 
 // Has checks if an element with the specified key exists within the cache
-// @param {interface{}} key - the key of the element
+// @param {K} key - the key of the element
 // @return {bool} - if an item with the specified key exists
-func (cache *LRUCache) Has(key interface{}) bool {
+func (cache *Cache[K, V]) Has(key K) bool {
 	cache.rwlock.RLock()
 	defer cache.rwlock.RUnlock()
 
 	_, ok := cache.items[key]
+	return ok
+}
+
+// HasFresh reports whether key has a non-stale entry, without promoting it. If stale
+// reports true for the current value, the entry is evicted under the same lock
+// acquisition and HasFresh reports false, analogous to GetFresh.
+func (cache *Cache[K, V]) HasFresh(key K, stale func(V) bool) bool {
+	cache.rwlock.Lock()
+	defer cache.rwlock.Unlock()
+
+	element, ok := cache.items[key]
+	if !ok {
+		return false
+	}
+
+	entry := element.Value.(*cacheEntry[K, V])
+	if stale != nil && stale(entry.value) {
+		cache.evictElement(element)
+		return false
+	}
+	return true
+}
+
+// Peek looks up an element without promoting it to the front of the recency list.
+func (cache *Cache[K, V]) Peek(key K) (V, bool) {
+	cache.rwlock.RLock()
+	defer cache.rwlock.RUnlock()
+
+	var zero V
+	element, ok := cache.items[key]
+	if !ok {
+		return zero, false
+	}
+	return element.Value.(*cacheEntry[K, V]).value, true
+}
+
+// Keys returns the keys currently in the cache, ordered most-recently-used first.
+func (cache *Cache[K, V]) Keys() []K {
+	cache.rwlock.RLock()
+	defer cache.rwlock.RUnlock()
+
+	keys := make([]K, 0, cache.orderedItems.Len())
+	for element := cache.orderedItems.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*cacheEntry[K, V]).key)
+	}
+	return keys
+}
+
+// Len returns the number of items currently in the cache.
+func (cache *Cache[K, V]) Len() int {
+	cache.rwlock.RLock()
+	defer cache.rwlock.RUnlock()
+
+	return cache.orderedItems.Len()
+}
+
+// Range walks the cache from most- to least-recently-used, calling f for each entry
+// without affecting recency. Iteration stops early if f returns false.
+func (cache *Cache[K, V]) Range(f func(key K, value V) bool) {
+	cache.rwlock.RLock()
+	defer cache.rwlock.RUnlock()
+
+	for element := cache.orderedItems.Front(); element != nil; element = element.Next() {
+		entry := element.Value.(*cacheEntry[K, V])
+		if !f(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// LRUItem is the value stored in an LRUCache.
+type LRUItem struct {
+	// Actual value stored for this specific item
+	Value interface{}
+	// The size for this item
+	Size int
 
+	// expiresAt is the time at which this item becomes a miss, or the zero
+	// Time if it never expires. Set via PutWithTTL or the WithDefaultTTL option.
+	expiresAt time.Time
+}
+
+// Option configures optional behaviour for a new LRUCache.
+type Option func(*LRUCache)
+
+// WithDefaultTTL makes every item admitted into the cache via Put, Replace, or the
+// producer expire after ttl, unless it was inserted with PutWithTTL specifying its
+// own ttl.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(cache *LRUCache) {
+		cache.defaultTTL = ttl
+	}
+}
+
+// LRUCache is a thin interface{}-based wrapper around the generic Cache, kept so
+// existing callers of this package aren't forced onto type parameters. New code
+// should prefer NewCache directly.
+type LRUCache struct {
+	core *Cache[interface{}, *LRUItem]
+
+	// defaultTTL, if non-zero, is applied to items that don't specify their own ttl
+	defaultTTL time.Duration
+
+	// expiryMu guards expiry and expiryIndex. expiry is the min-heap used to find
+	// due entries without scanning the whole cache on every access; expiryIndex
+	// maps a key to its live heap entry so clearExpiry can remove it in O(log n)
+	// as soon as the item is evicted for any reason, not just via Purge.
+	expiryMu    sync.Mutex
+	expiry      expiryHeap
+	expiryIndex map[interface{}]*expiryEntry
+
+	// janitorMu guards janitorStop against concurrent StartJanitor/StopJanitor calls.
+	janitorMu   sync.Mutex
+	janitorStop chan struct{}
+
+	// onevict is the raw user callback, kept alongside coreOnEvict so it can be
+	// dispatched outside the core's lock when evictBuffer is in use
+	onevict func(interface{}, *LRUItem)
+
+	// evictMu guards evictBuffer
+	evictMu sync.Mutex
+	// evictBuffer holds pending evictions awaiting dispatch once WithEvictBuffer
+	// is set; nil means evictions are dispatched inline, as before
+	evictBuffer []Evicted
+}
+
+// Evicted is a (key, item) pair removed from the cache, as buffered by WithEvictBuffer
+// and returned by Drain.
+type Evicted struct {
+	Key  interface{}
+	Item *LRUItem
+}
+
+// WithEvictBuffer makes eviction callbacks fire after the cache's internal lock has
+// been released instead of from inside evictElement, so a slow onevict (logging,
+// writing evicted blobs to disk) no longer stalls every other Get/Put while it runs.
+// Evictions are appended to a buffer of initial capacity n during eviction and
+// dispatched right after the triggering call returns. Callers who'd rather not use a
+// callback at all can pull the buffered batch synchronously with Drain instead.
+func WithEvictBuffer(n int) Option {
+	return func(cache *LRUCache) {
+		cache.evictBuffer = make([]Evicted, 0, n)
+	}
+}
+
+// New Creates a new LRW cache
+// @param {maxSize} - the maximum size of this cache
+// @param {producer} - optional callback function which gets invoked on cache misses. The
+// 	result of this callback is stored in the cache for the key which was missing.
+// 	Can be null, in which case all cache misses will return null
+// @param {onevict} - optional function which will get called when an item is evicted from the cache
+// @param {opts} - optional behaviour, e.g. WithDefaultTTL
+func New(maxSize int, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem), opts ...Option) (*LRUCache, error) {
+	cache := &LRUCache{}
+	for _, opt := range opts {
+		opt(cache)
+	}
+
+	coreProducer := func(key interface{}) (*LRUItem, int, bool) {
+		if producer == nil {
+			return nil, 0, false
+		}
+		item := producer(key)
+		if item == nil {
+			return nil, 0, false
+		}
+		cache.armTTL(key, item, 0)
+		return item, item.Size, true
+	}
+
+	cache.onevict = onevict
+	coreOnEvict := func(key interface{}, item *LRUItem, size int) {
+		cache.clearExpiry(key, item)
+		if cache.evictBuffer != nil {
+			cache.evictMu.Lock()
+			cache.evictBuffer = append(cache.evictBuffer, Evicted{Key: key, Item: item})
+			cache.evictMu.Unlock()
+			return
+		}
+		if cache.onevict != nil {
+			cache.onevict(key, item)
+		}
+	}
+
+	core, err := NewCache[interface{}, *LRUItem](maxSize, coreProducer, coreOnEvict)
+	if err != nil {
+		return nil, err
+	}
+	cache.core = core
+	return cache, nil
+}
+
+// Drain returns and clears the batch of evictions buffered since the last Drain.
+// Only meaningful when WithEvictBuffer was set; otherwise it always returns nil.
+func (cache *LRUCache) Drain() []Evicted {
+	cache.evictMu.Lock()
+	defer cache.evictMu.Unlock()
+
+	if cache.evictBuffer == nil {
+		return nil
+	}
+	batch := cache.evictBuffer
+	cache.evictBuffer = make([]Evicted, 0, cap(batch))
+	return batch
+}
+
+// dispatchEvicted fires onevict for every eviction buffered by WithEvictBuffer since
+// the last dispatch. It must only be called once the core's lock has been released,
+// i.e. after a core.* call has already returned. A no-op unless both WithEvictBuffer
+// and onevict were set; callers who only set WithEvictBuffer use Drain instead.
+func (cache *LRUCache) dispatchEvicted() {
+	if cache.evictBuffer == nil || cache.onevict == nil {
+		return
+	}
+	for _, evicted := range cache.Drain() {
+		cache.onevict(evicted.Key, evicted.Item)
+	}
+}
+
+// Get retrieves an element from the cache. In case of cache miss and a producer is defined, then
+// the producer is invoked and the result is stored in the cache. If the producer is null, no
+// result is returned (nil return value)
+//
+// @param key - the key to search in the cache
+// @return - if found, returns the corresponding cached element. If not found, the result
+// 	can either be null if the producer was not given, or the retult of the producer
+func (cache *LRUCache) Get(key interface{}) *LRUItem {
+	item, ok := cache.core.GetFresh(key, cache.expired)
+	cache.dispatchEvicted()
+	if !ok {
+		return nil
+	}
+	return item
+}
+
+// takeWithoutEvict removes key from the cache without invoking onevict. Used internally
+// by TwoQueueCache to promote an entry between its sub-caches.
+func (cache *LRUCache) takeWithoutEvict(key interface{}) *LRUItem {
+	item, _ := cache.core.takeWithoutEvict(key)
+	return item
+}
+
+// MakeRoom evicts elements from the cache until the specified empty space is made. Ff the
+// cache already has enough empty space, then no elements are evicted.
+// @param  {int} size - how much empty space should be ensured
+func (cache *LRUCache) MakeRoom(size int) {
+	cache.core.MakeRoom(size)
+	cache.dispatchEvicted()
+}
+
+// Put adds a new element to the cache. If an item with the same key already exists,
+// then the operation fails and an error is returned.
+// @param {interface{}} key - the key for the new cached item
+// @param {*LRUItem} element - the element to be inserted
+func (cache *LRUCache) Put(key interface{}, element *LRUItem) error {
+	cache.armTTL(key, element, 0)
+	err := cache.core.Put(key, element, element.Size)
+	cache.dispatchEvicted()
+	return err
+}
+
+// Replace adds a new element to the cache. If an item with the same key already exists,
+// then it is evicted and replaced with the given element.
+// @param {interface{}} key - the key for the new cached item
+// @param {*LRUItem} element - the element to be inserted
+func (cache *LRUCache) Replace(key interface{}, element *LRUItem) error {
+	cache.armTTL(key, element, 0)
+	err := cache.core.Replace(key, element, element.Size)
+	cache.dispatchEvicted()
+	return err
+}
+
+// Evict removes an element from the cache.
+// @param {interface{}} key - the key of the element to be removed
+// @return {*LRUItem} - if an element with the specified key is found and removed, then the return
+// value is the deleted element. Otherwise, nil is returned.
+func (cache *LRUCache) Evict(key interface{}) *LRUItem {
+	item, _ := cache.core.Evict(key)
+	cache.dispatchEvicted()
+	return item
+}
+
+// EvictIf removes every element whose key matches predicate.
+// @param {func(interface{}) bool} predicate - called once per key currently in the cache
+func (cache *LRUCache) EvictIf(predicate func(interface{}) bool) *LRUItem {
+	cache.core.EvictIf(predicate)
+	cache.dispatchEvicted()
+	return nil
+}
+
+// EmptySpace returns the remaining empty space within the cache.
+func (cache *LRUCache) EmptySpace() int {
+	return cache.core.EmptySpace()
+}
+
+// MaxSize returns the maximum size this cache can hold.
+func (cache *LRUCache) MaxSize() int {
+	return cache.core.MaxSize()
+}
+
+// Has checks if an element with the specified key exists within the cache
+// @param {interface{}} key - the key of the element
+// @return {bool} - if an item with the specified key exists
+func (cache *LRUCache) Has(key interface{}) bool {
+	ok := cache.core.HasFresh(key, cache.expired)
+	cache.dispatchEvicted()
 	return ok
 }
+
+// Keys returns the keys currently in the cache, ordered most-recently-used first.
+func (cache *LRUCache) Keys() []interface{} {
+	return cache.core.Keys()
+}
+
+// Len returns the number of items currently in the cache.
+func (cache *LRUCache) Len() int {
+	return cache.core.Len()
+}
+
+// Range walks the cache from most- to least-recently-used, calling f for each entry
+// without affecting recency. Iteration stops early if f returns false.
+func (cache *LRUCache) Range(f func(key interface{}, item *LRUItem) bool) {
+	cache.core.Range(f)
+}
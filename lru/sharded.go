@@ -0,0 +1,102 @@
+package lru
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// ShardedLRUCache wraps N independent LRUCache shards, keyed by fnv64(key) % N, to
+// reduce write-lock contention. LRUCache takes a single sync.RWMutex write lock even
+// on Get (to move the accessed item to the front), so heavy concurrent reads serialize
+// on one lock; spreading keys across shards gives each its own lock instead.
+type ShardedLRUCache struct {
+	shards []*LRUCache
+}
+
+// NewSharded creates a ShardedLRUCache of the given number of shards, each sized
+// maxSizePerShard, sharing the same producer and onevict across all shards.
+func NewSharded(shards int, maxSizePerShard int, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem)) (*ShardedLRUCache, error) {
+	if shards <= 0 {
+		return nil, errors.New("Shard count must be greater than 0")
+	}
+
+	cache := &ShardedLRUCache{shards: make([]*LRUCache, shards)}
+	for i := range cache.shards {
+		shard, err := New(maxSizePerShard, producer, onevict)
+		if err != nil {
+			return nil, err
+		}
+		cache.shards[i] = shard
+	}
+	return cache, nil
+}
+
+// shardFor picks the shard responsible for key by hashing its string representation
+// with fnv64a.
+func (cache *ShardedLRUCache) shardFor(key interface{}) *LRUCache {
+	hasher := fnv.New64a()
+	fmt.Fprintf(hasher, "%v", key)
+	return cache.shards[hasher.Sum64()%uint64(len(cache.shards))]
+}
+
+// Get retrieves an element from the cache, delegating to the shard responsible for key.
+func (cache *ShardedLRUCache) Get(key interface{}) *LRUItem {
+	return cache.shardFor(key).Get(key)
+}
+
+// Put adds a new element to the cache. If an item with the same key already exists
+// in its shard, then the operation fails and an error is returned.
+func (cache *ShardedLRUCache) Put(key interface{}, element *LRUItem) error {
+	return cache.shardFor(key).Put(key, element)
+}
+
+// Replace adds a new element to the cache. If an item with the same key already exists
+// in its shard, then it is evicted and replaced with the given element.
+func (cache *ShardedLRUCache) Replace(key interface{}, element *LRUItem) error {
+	return cache.shardFor(key).Replace(key, element)
+}
+
+// Evict removes an element from the cache.
+func (cache *ShardedLRUCache) Evict(key interface{}) *LRUItem {
+	return cache.shardFor(key).Evict(key)
+}
+
+// EvictIf removes every element whose key matches predicate, across all shards.
+func (cache *ShardedLRUCache) EvictIf(predicate func(interface{}) bool) *LRUItem {
+	for _, shard := range cache.shards {
+		shard.EvictIf(predicate)
+	}
+	return nil
+}
+
+// Has checks if an element with the specified key exists within the cache.
+func (cache *ShardedLRUCache) Has(key interface{}) bool {
+	return cache.shardFor(key).Has(key)
+}
+
+// EmptySpace returns the combined remaining empty space across all shards.
+func (cache *ShardedLRUCache) EmptySpace() int {
+	total := 0
+	for _, shard := range cache.shards {
+		total += shard.EmptySpace()
+	}
+	return total
+}
+
+// MaxSize returns the combined maximum size held across all shards.
+func (cache *ShardedLRUCache) MaxSize() int {
+	total := 0
+	for _, shard := range cache.shards {
+		total += shard.MaxSize()
+	}
+	return total
+}
+
+// MakeRoom evicts elements from every shard until the specified empty space is made
+// in each one. @param {int} size - how much empty space should be ensured, per shard
+func (cache *ShardedLRUCache) MakeRoom(size int) {
+	for _, shard := range cache.shards {
+		shard.MakeRoom(size)
+	}
+}
@@ -0,0 +1,110 @@
+package lru
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSharded_PutAndGet(t *testing.T) {
+	cache, _ := NewSharded(4, 128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+
+	if !cache.Has("test1") {
+		t.Fail()
+	}
+	if cache.Get("test1").Value != "test1" {
+		t.Fail()
+	}
+}
+
+func TestSharded_MaxSizeAndEmptySpace(t *testing.T) {
+	cache, _ := NewSharded(4, 128, nil, nil)
+
+	if cache.MaxSize() != 4*128 {
+		t.Fail()
+	}
+	if cache.EmptySpace() != 4*128 {
+		t.Fail()
+	}
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+
+	if cache.EmptySpace() != 4*128-50 {
+		t.Fail()
+	}
+}
+
+func TestSharded_Evict(t *testing.T) {
+	cache, _ := NewSharded(4, 128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+	evicted := cache.Evict("test1")
+
+	if evicted == nil {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestSharded_EvictIf(t *testing.T) {
+	cache, _ := NewSharded(4, 128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 10})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 10})
+	cache.Put("test3", &LRUItem{Value: "test3", Size: 10})
+
+	cache.EvictIf(func(key interface{}) bool {
+		return key == "test2"
+	})
+
+	if !cache.Has("test1") || cache.Has("test2") || !cache.Has("test3") {
+		t.Fail()
+	}
+}
+
+func TestSharded_KeysSpreadAcrossShards(t *testing.T) {
+	cache, _ := NewSharded(8, 128, nil, nil)
+
+	for i := 0; i < 64; i++ {
+		cache.Put(strconv.Itoa(i), &LRUItem{Value: i, Size: 1})
+	}
+
+	used := make(map[*LRUCache]bool)
+	for i := 0; i < 64; i++ {
+		used[cache.shardFor(strconv.Itoa(i))] = true
+	}
+	if len(used) < 2 {
+		t.Fail()
+	}
+}
+
+func BenchmarkLRUCache_ConcurrentGet(b *testing.B) {
+	cache, _ := New(1<<20, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 1}
+	}, nil)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Get(strconv.Itoa(i % 1000))
+			i++
+		}
+	})
+}
+
+func BenchmarkShardedLRUCache_ConcurrentGet(b *testing.B) {
+	cache, _ := NewSharded(16, 1<<16, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 1}
+	}, nil)
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			cache.Get(strconv.Itoa(i % 1000))
+			i++
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package lru
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+func init() {
+	// Register the built-in types exercised by this package's own tests. Callers
+	// storing their own types must gob.Register them too; see Snapshot.
+	gob.Register("")
+	gob.Register(0)
+}
+
+// snapshotEntry is the on-disk representation of one cached item.
+type snapshotEntry struct {
+	Key   interface{}
+	Size  int
+	Value interface{}
+}
+
+// Snapshot serializes every item currently in the cache to w with encoding/gob, in
+// most-recently-used to least-recently-used order, so that Restore can rehydrate a
+// warm cache after a process restart.
+//
+// Values must implement gob.GobEncoder or have their concrete type registered with
+// gob.Register; an entry whose value can't be gob-encoded is reported as an error and
+// aborts the snapshot.
+func (cache *LRUCache) Snapshot(w io.Writer) error {
+	// Collect every entry under a single Range walk so the count written below
+	// always matches what gets encoded, even if a concurrent eviction happens
+	// right after: Range holds the core's lock for its entire walk, so there's
+	// no gap between reading the key set and reading its values for this to
+	// race with (unlike reading cache.Keys() and then Peek-ing each one).
+	var entries []snapshotEntry
+	cache.core.Range(func(key interface{}, item *LRUItem) bool {
+		entries = append(entries, snapshotEntry{Key: key, Size: item.Size, Value: item.Value})
+		return true
+	})
+
+	encoder := gob.NewEncoder(w)
+	if err := encoder.Encode(len(entries)); err != nil {
+		return fmt.Errorf("lru: snapshot: %w", err)
+	}
+
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("lru: snapshot: value for key %v is not gob-encodable, see gob.Register: %w", entry.Key, err)
+		}
+	}
+	return nil
+}
+
+// Restore rebuilds an LRUCache of the given maxSize from a snapshot written by
+// Snapshot, re-inserting entries from least- to most-recently-used so the restored
+// recency order matches the snapshot. maxSize is taken as-is, just like New's, so
+// callers get back a cache with the same headroom the original had rather than one
+// sized to exactly fit the restored items.
+func Restore(maxSize int, r io.Reader, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem)) (*LRUCache, error) {
+	decoder := gob.NewDecoder(r)
+
+	var count int
+	if err := decoder.Decode(&count); err != nil {
+		return nil, fmt.Errorf("lru: restore: %w", err)
+	}
+
+	entries := make([]snapshotEntry, count)
+	for i := range entries {
+		if err := decoder.Decode(&entries[i]); err != nil {
+			return nil, fmt.Errorf("lru: restore: entry %d: %w", i, err)
+		}
+	}
+
+	cache, err := New(maxSize, producer, onevict)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if err := cache.Put(entry.Key, &LRUItem{Value: entry.Value, Size: entry.Size}); err != nil {
+			return nil, fmt.Errorf("lru: restore: entry for key %v: %w", entry.Key, err)
+		}
+	}
+	return cache, nil
+}
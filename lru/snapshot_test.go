@@ -0,0 +1,136 @@
+package lru
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeysLenRange(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 10})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 10})
+	cache.Get("test1")
+
+	if cache.Len() != 2 {
+		t.Fail()
+	}
+
+	keys := cache.Keys()
+	if len(keys) != 2 || keys[0] != "test1" || keys[1] != "test2" {
+		t.Fail()
+	}
+
+	seen := ""
+	cache.Range(func(key interface{}, item *LRUItem) bool {
+		seen += key.(string)
+		return true
+	})
+	if seen != "test1test2" {
+		t.Fail()
+	}
+}
+
+func TestRange_StopsEarly(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 10})
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 10})
+
+	seen := ""
+	cache.Range(func(key interface{}, item *LRUItem) bool {
+		seen += key.(string)
+		return false
+	})
+	if seen != "test2" {
+		t.Fail()
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "value1", Size: 20})
+	cache.Put("test2", &LRUItem{Value: "value2", Size: 30})
+	cache.Get("test1")
+
+	var buf bytes.Buffer
+	if err := cache.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := Restore(128, &buf, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.MaxSize() != 128 {
+		t.Fail()
+	}
+	if restored.EmptySpace() != 78 {
+		t.Fail()
+	}
+
+	keys := restored.Keys()
+	if len(keys) != 2 || keys[0] != "test1" || keys[1] != "test2" {
+		t.Fail()
+	}
+
+	if restored.Get("test1").Value != "value1" {
+		t.Fail()
+	}
+	if restored.Get("test2").Value != "value2" {
+		t.Fail()
+	}
+
+	// A freshly restored cache must keep the original headroom: inserting one more
+	// small item shouldn't immediately evict one of the just-restored warm items.
+	restored.Put("test3", &LRUItem{Value: "value3", Size: 10})
+	if !restored.Has("test1") || !restored.Has("test2") {
+		t.Fail()
+	}
+}
+
+func TestSnapshot_CountMatchesEncodedEntries(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.Put("test1", &LRUItem{Value: "value1", Size: 10})
+	cache.Put("test2", &LRUItem{Value: "value2", Size: 10})
+	cache.Put("test3", &LRUItem{Value: "value3", Size: 10})
+
+	// Snapshot must derive its count from the same walk that produces the
+	// entries, so it can never claim more (or fewer) than it actually encodes,
+	// even if the cache is mutated concurrently with the walk.
+	var buf bytes.Buffer
+	if err := cache.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := Restore(128, &buf, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Len() != 3 {
+		t.Fail()
+	}
+}
+
+func TestSnapshotRestore_Empty(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	var buf bytes.Buffer
+	if err := cache.Snapshot(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	restored, err := Restore(128, &buf, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Len() != 0 {
+		t.Fail()
+	}
+	if restored.MaxSize() != 128 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,178 @@
+package lru
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expiryEntry is a (expiresAt, item) pair tracked in the expiry min-heap, so that
+// Purge and the janitor goroutine can find due entries in O(log n) instead of
+// scanning every item in the cache on every sweep. index is maintained by
+// expiryHeap.Swap so a live entry can be removed from the heap in O(log n) from
+// clearExpiry, regardless of what caused the item to be evicted.
+type expiryEntry struct {
+	key       interface{}
+	expiresAt time.Time
+	item      *LRUItem
+	index     int
+}
+
+type expiryHeap []*expiryEntry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+func (h *expiryHeap) Push(x interface{}) {
+	entry := x.(*expiryEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	entry.index = -1
+	*h = old[:n-1]
+	return entry
+}
+
+// expired reports whether item's expiration, if any, has passed.
+func (cache *LRUCache) expired(item *LRUItem) bool {
+	return !item.expiresAt.IsZero() && time.Now().After(item.expiresAt)
+}
+
+// armTTL sets item's expiration, preferring the explicit ttl when given and
+// otherwise falling back to the cache's default TTL, then tracks it in the
+// expiry heap. A non-positive ttl with no default configured leaves item
+// without an expiration. Any stale heap entry already held for key (e.g. from a
+// Replace) is dropped first so at most one heap entry per key is ever live.
+func (cache *LRUCache) armTTL(key interface{}, item *LRUItem, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = cache.defaultTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	item.expiresAt = time.Now().Add(ttl)
+
+	cache.expiryMu.Lock()
+	defer cache.expiryMu.Unlock()
+
+	if cache.expiryIndex == nil {
+		cache.expiryIndex = make(map[interface{}]*expiryEntry)
+	}
+	if old, ok := cache.expiryIndex[key]; ok {
+		heap.Remove(&cache.expiry, old.index)
+	}
+
+	entry := &expiryEntry{key: key, expiresAt: item.expiresAt, item: item}
+	heap.Push(&cache.expiry, entry)
+	cache.expiryIndex[key] = entry
+}
+
+// clearExpiry drops key's entry from the expiry heap, if any, as long as it still
+// refers to item. This is called for every eviction regardless of cause (plain LRU
+// pressure, an explicit Evict, a Replace's old value, or lazy TTL expiry in Get/Has),
+// so the heap never accumulates entries for items that are already gone.
+func (cache *LRUCache) clearExpiry(key interface{}, item *LRUItem) {
+	cache.expiryMu.Lock()
+	defer cache.expiryMu.Unlock()
+
+	entry, ok := cache.expiryIndex[key]
+	if !ok || entry.item != item {
+		return
+	}
+	heap.Remove(&cache.expiry, entry.index)
+	delete(cache.expiryIndex, key)
+}
+
+// PutWithTTL adds a new element to the cache that expires after ttl, overriding
+// any WithDefaultTTL configured on the cache. If an item with the same key
+// already exists, then the operation fails and an error is returned.
+func (cache *LRUCache) PutWithTTL(key interface{}, item *LRUItem, ttl time.Duration) error {
+	cache.armTTL(key, item, ttl)
+	err := cache.core.Put(key, item, item.Size)
+	cache.dispatchEvicted()
+	return err
+}
+
+// Purge sweeps all expired entries from the cache in a single pass, firing
+// onevict for each one removed, and returns how many were purged.
+func (cache *LRUCache) Purge() int {
+	now := time.Now()
+	purged := 0
+
+	for {
+		entry, ok := cache.popDueEntry(now)
+		if !ok {
+			break
+		}
+
+		if _, evicted := cache.core.EvictIfCurrent(entry.key, func(current *LRUItem) bool { return current == entry.item }); evicted {
+			purged++
+		}
+	}
+	cache.dispatchEvicted()
+	return purged
+}
+
+// popDueEntry pops the next expiry-heap entry whose expiresAt is at or before
+// now, if any. Entries popped here may be stale (superseded by a Replace or
+// already gone via a size eviction); Purge is responsible for checking that.
+func (cache *LRUCache) popDueEntry(now time.Time) (*expiryEntry, bool) {
+	cache.expiryMu.Lock()
+	defer cache.expiryMu.Unlock()
+
+	if cache.expiry.Len() == 0 || cache.expiry[0].expiresAt.After(now) {
+		return nil, false
+	}
+	entry := heap.Pop(&cache.expiry).(*expiryEntry)
+	delete(cache.expiryIndex, entry.key)
+	return entry, true
+}
+
+// StartJanitor starts a background goroutine that calls Purge every interval,
+// until StopJanitor is called. Starting a janitor while one is already running
+// replaces it.
+func (cache *LRUCache) StartJanitor(interval time.Duration) {
+	cache.janitorMu.Lock()
+	defer cache.janitorMu.Unlock()
+
+	if cache.janitorStop != nil {
+		close(cache.janitorStop)
+	}
+
+	stop := make(chan struct{})
+	cache.janitorStop = stop
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				cache.Purge()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopJanitor stops the background goroutine started by StartJanitor, if any.
+func (cache *LRUCache) StopJanitor() {
+	cache.janitorMu.Lock()
+	defer cache.janitorMu.Unlock()
+
+	if cache.janitorStop != nil {
+		close(cache.janitorStop)
+		cache.janitorStop = nil
+	}
+}
@@ -0,0 +1,202 @@
+package lru
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTTL_GetMissesAfterExpiry(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, 10*time.Millisecond)
+
+	if cache.Get("test1") == nil {
+		t.Fail()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if cache.Get("test1") != nil {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTTL_OnEvictFiresOnExpiry(t *testing.T) {
+	evicted := ""
+
+	cache, _ := New(128, nil, func(key interface{}, item *LRUItem) {
+		evicted += key.(string)
+	})
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, 10*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	cache.Get("test1")
+
+	if evicted != "test1" {
+		t.Fail()
+	}
+}
+
+func TestTTL_DefaultTTLOption(t *testing.T) {
+	cache, _ := New(128, nil, nil, WithDefaultTTL(10*time.Millisecond))
+
+	cache.Put("test1", &LRUItem{Value: "test1", Size: 10})
+	time.Sleep(20 * time.Millisecond)
+
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTTL_ExplicitTTLOverridesDefault(t *testing.T) {
+	cache, _ := New(128, nil, nil, WithDefaultTTL(10*time.Millisecond))
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, time.Hour)
+	time.Sleep(20 * time.Millisecond)
+
+	if !cache.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTTL_Purge(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, 10*time.Millisecond)
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 10})
+
+	time.Sleep(20 * time.Millisecond)
+
+	if purged := cache.Purge(); purged != 1 {
+		t.Fail()
+	}
+	if cache.Has("test1") {
+		t.Fail()
+	}
+	if !cache.Has("test2") {
+		t.Fail()
+	}
+}
+
+func TestTTL_LazyEvictionDoesNotLeakHeapEntries(t *testing.T) {
+	cache, _ := New(10000, nil, nil)
+
+	for i := 0; i < 500; i++ {
+		key := strconv.Itoa(i)
+		cache.PutWithTTL(key, &LRUItem{Value: key, Size: 1}, 10*time.Millisecond)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	// Lazily evict every key via Get/Has alone, no Purge or janitor involved.
+	for i := 0; i < 500; i++ {
+		key := strconv.Itoa(i)
+		if cache.Get(key) != nil {
+			t.Fail()
+		}
+	}
+
+	if cache.expiry.Len() != 0 {
+		t.Fail()
+	}
+	if len(cache.expiryIndex) != 0 {
+		t.Fail()
+	}
+}
+
+func TestTTL_ReplaceDoesNotLeaveDanglingHeapEntry(t *testing.T) {
+	cache, _ := New(128, nil, nil, WithDefaultTTL(time.Hour))
+
+	cache.Put("test1", &LRUItem{Value: "v1", Size: 10})
+	cache.Replace("test1", &LRUItem{Value: "v2", Size: 10})
+
+	// Replacing test1 should have dropped the stale heap entry for "v1" rather
+	// than leaving two entries (or the old item's onevict clearing the new one).
+	if cache.expiry.Len() != 1 {
+		t.Fail()
+	}
+
+	item := cache.Get("test1")
+	if item == nil || item.Value != "v2" {
+		t.Fail()
+	}
+}
+
+func TestTTL_PlainEvictionClearsHeapEntry(t *testing.T) {
+	cache, _ := New(20, nil, nil)
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, time.Hour)
+	// Pushes "test1" out via plain LRU pressure, unrelated to its TTL.
+	cache.Put("test2", &LRUItem{Value: "test2", Size: 20})
+
+	if cache.Has("test1") {
+		t.Fail()
+	}
+	if cache.expiry.Len() != 0 {
+		t.Fail()
+	}
+}
+
+func TestTTL_ConcurrentGetAndReplaceIsRaceFree(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+	cache.PutWithTTL("test1", &LRUItem{Value: "v0", Size: 10}, time.Microsecond)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				cache.Get("test1")
+				cache.Has("test1")
+			}
+		}()
+	}
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				cache.Replace("test1", &LRUItem{Value: "v1", Size: 10})
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTTL_ConcurrentStartStopJanitorIsRaceFree(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				cache.StartJanitor(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	cache.StopJanitor()
+}
+
+func TestTTL_Janitor(t *testing.T) {
+	cache, _ := New(128, nil, nil)
+
+	cache.PutWithTTL("test1", &LRUItem{Value: "test1", Size: 10}, 10*time.Millisecond)
+	cache.StartJanitor(5 * time.Millisecond)
+	defer cache.StopJanitor()
+
+	time.Sleep(50 * time.Millisecond)
+
+	if cache.Has("test1") {
+		t.Fail()
+	}
+}
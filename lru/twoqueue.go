@@ -0,0 +1,191 @@
+package lru
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// Default fraction of maxSize reserved for the "recent" queue. The remainder is
+// given to the "frequent" queue.
+const defaultRecentRatio = 0.25
+
+// Default number of keys retained in the "ghost" queue. The ghost queue only
+// stores keys (no values), so its budget is a key count rather than a byte size.
+const defaultGhostCapacity = 512
+
+// TwoQueueCache is a 2Q admission policy variant of LRUCache, useful for
+// workloads that mix one-off sequential scans with a smaller set of
+// frequently re-read items (e.g. blob storage). It partitions maxSize between
+// two size-bounded LRUCache instances:
+//   - "recent" holds items that have been seen exactly once
+//   - "frequent" holds items that have been seen at least twice
+//
+// A third "ghost" queue remembers the keys (not the values) of items recently
+// evicted from "recent", so that a cache miss which hits the ghost queue is
+// recognised as a re-reference and admitted directly into "frequent" instead
+// of "recent" again. This gives scan resistance: a single large sequential
+// pass through "recent" won't flush out the working set held in "frequent".
+type TwoQueueCache struct {
+	recent   *LRUCache
+	frequent *LRUCache
+
+	// ghostMu guards ghostKeys/ghostIndex, since addGhost is invoked from recent's
+	// onevict callback (under recent's lock) while removeGhost is invoked directly
+	// from Get with no lock of its own
+	ghostMu       sync.Mutex
+	ghostCapacity int
+	ghostKeys     *list.List
+	ghostIndex    map[interface{}]*list.Element
+
+	producer func(key interface{}) *LRUItem
+	onevict  func(interface{}, *LRUItem)
+}
+
+// NewTwoQueue creates a new 2Q cache using the default ratios: ~25% of
+// maxSize for the "recent" queue (the rest goes to "frequent") and a ghost
+// queue capacity of defaultGhostCapacity keys.
+func NewTwoQueue(maxSize int, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem)) (*TwoQueueCache, error) {
+	return NewTwoQueueWithRatio(maxSize, defaultRecentRatio, defaultGhostCapacity, producer, onevict)
+}
+
+// NewTwoQueueWithRatio creates a new 2Q cache with a custom "recent" ratio
+// (0 < recentRatio < 1, the share of maxSize given to the "recent" queue) and
+// a custom ghost queue key capacity.
+func NewTwoQueueWithRatio(maxSize int, recentRatio float64, ghostCapacity int, producer func(key interface{}) *LRUItem, onevict func(interface{}, *LRUItem)) (*TwoQueueCache, error) {
+	recentSize := int(float64(maxSize) * recentRatio)
+	if recentSize <= 0 {
+		recentSize = 1
+	}
+	frequentSize := maxSize - recentSize
+	if frequentSize <= 0 {
+		frequentSize = 1
+	}
+
+	cache := &TwoQueueCache{
+		ghostCapacity: ghostCapacity,
+		ghostKeys:     list.New(),
+		ghostIndex:    make(map[interface{}]*list.Element),
+		producer:      producer,
+		onevict:       onevict,
+	}
+
+	frequent, err := New(frequentSize, nil, func(key interface{}, item *LRUItem) {
+		if cache.onevict != nil {
+			cache.onevict(key, item)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := New(recentSize, nil, func(key interface{}, item *LRUItem) {
+		cache.addGhost(key)
+		if cache.onevict != nil {
+			cache.onevict(key, item)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cache.recent = recent
+	cache.frequent = frequent
+	return cache, nil
+}
+
+// Get retrieves an element from the cache, applying the 2Q promotion rules:
+// a hit in "recent" promotes the item to "frequent", a hit in "frequent"
+// simply moves it to the front, and a miss that hits the "ghost" queue causes
+// the produced item to be admitted straight into "frequent". On a cache miss
+// without a producer, nil is returned.
+func (cache *TwoQueueCache) Get(key interface{}) *LRUItem {
+	if item := cache.takeFromRecent(key); item != nil {
+		cache.frequent.Put(key, item)
+		return item
+	}
+
+	if item := cache.frequent.Get(key); item != nil {
+		return item
+	}
+
+	wasGhost := cache.removeGhost(key)
+
+	if cache.producer == nil {
+		return nil
+	}
+	item := cache.producer(key)
+	if item == nil {
+		return nil
+	}
+
+	if wasGhost {
+		cache.frequent.Put(key, item)
+	} else {
+		cache.recent.Put(key, item)
+	}
+	return item
+}
+
+// Put inserts an element directly into the "recent" queue, bypassing the
+// producer. If the key already exists in either queue, an error is returned.
+func (cache *TwoQueueCache) Put(key interface{}, item *LRUItem) error {
+	if cache.recent.Has(key) || cache.frequent.Has(key) {
+		return errors.New("Key already exists")
+	}
+	return cache.recent.Put(key, item)
+}
+
+// Has reports whether key is present in either the "recent" or "frequent" queue.
+func (cache *TwoQueueCache) Has(key interface{}) bool {
+	return cache.recent.Has(key) || cache.frequent.Has(key)
+}
+
+// EmptySpace returns the combined remaining empty space across both queues.
+func (cache *TwoQueueCache) EmptySpace() int {
+	return cache.recent.EmptySpace() + cache.frequent.EmptySpace()
+}
+
+// takeFromRecent removes key from the "recent" queue without firing onevict
+// or touching the ghost queue, since this is a promotion rather than a real
+// eviction. Returns nil if key is not present in "recent".
+func (cache *TwoQueueCache) takeFromRecent(key interface{}) *LRUItem {
+	return cache.recent.takeWithoutEvict(key)
+}
+
+func (cache *TwoQueueCache) addGhost(key interface{}) {
+	if cache.ghostCapacity <= 0 {
+		return
+	}
+
+	cache.ghostMu.Lock()
+	defer cache.ghostMu.Unlock()
+
+	if _, ok := cache.ghostIndex[key]; ok {
+		return
+	}
+
+	element := cache.ghostKeys.PushFront(key)
+	cache.ghostIndex[key] = element
+
+	for cache.ghostKeys.Len() > cache.ghostCapacity {
+		back := cache.ghostKeys.Back()
+		cache.ghostKeys.Remove(back)
+		delete(cache.ghostIndex, back.Value)
+	}
+}
+
+// removeGhost removes key from the ghost queue, reporting whether it was present.
+func (cache *TwoQueueCache) removeGhost(key interface{}) bool {
+	cache.ghostMu.Lock()
+	defer cache.ghostMu.Unlock()
+
+	element, ok := cache.ghostIndex[key]
+	if !ok {
+		return false
+	}
+
+	cache.ghostKeys.Remove(element)
+	delete(cache.ghostIndex, key)
+	return true
+}
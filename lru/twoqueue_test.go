@@ -0,0 +1,130 @@
+package lru
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestTwoQueue_MissGoesToRecent(t *testing.T) {
+	cache, _ := NewTwoQueue(400, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, nil)
+
+	cache.Get("test1")
+
+	if !cache.recent.Has("test1") {
+		t.Fail()
+	}
+	if cache.frequent.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_SecondHitPromotesToFrequent(t *testing.T) {
+	cache, _ := NewTwoQueue(400, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, nil)
+
+	cache.Get("test1")
+	cache.Get("test1")
+
+	if cache.recent.Has("test1") {
+		t.Fail()
+	}
+	if !cache.frequent.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_GhostHitAdmitsToFrequent(t *testing.T) {
+	cache, _ := NewTwoQueueWithRatio(200, 0.25, 10, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, nil)
+
+	// Fill and overflow the "recent" queue (50 budget) so test1 is evicted into the ghost queue.
+	cache.Get("test1")
+	cache.Get("test2")
+
+	if cache.Has("test1") {
+		t.Fail()
+	}
+
+	cache.Get("test1")
+
+	if !cache.frequent.Has("test1") {
+		t.Fail()
+	}
+	if cache.recent.Has("test1") {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_OnEvictFiresOnRealEviction(t *testing.T) {
+	evicted := ""
+
+	cache, _ := NewTwoQueueWithRatio(100, 0.5, 10, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, func(key interface{}, item *LRUItem) {
+		evicted += key.(string)
+	})
+
+	cache.Get("test1")
+	cache.Get("test2")
+
+	if evicted != "test1" {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_PromotionDoesNotFireOnEvict(t *testing.T) {
+	evicted := ""
+
+	cache, _ := NewTwoQueue(400, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, func(key interface{}, item *LRUItem) {
+		evicted += key.(string)
+	})
+
+	cache.Get("test1")
+	cache.Get("test1")
+
+	if evicted != "" {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_Put(t *testing.T) {
+	cache, _ := NewTwoQueue(400, nil, nil)
+
+	err := cache.Put("test1", &LRUItem{Value: "test1", Size: 50})
+
+	if err != nil {
+		t.Fail()
+	}
+	if !cache.Has("test1") {
+		t.Fail()
+	}
+
+	if cache.Put("test1", &LRUItem{Value: "test1", Size: 50}) == nil {
+		t.Fail()
+	}
+}
+
+func TestTwoQueue_ConcurrentGetIsRaceFree(t *testing.T) {
+	cache, _ := NewTwoQueueWithRatio(400, 0.25, 8, func(key interface{}) *LRUItem {
+		return &LRUItem{Value: key, Size: 50}
+	}, nil)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 32; g++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				cache.Get(strconv.Itoa(i % 4))
+			}
+		}(g)
+	}
+	wg.Wait()
+}